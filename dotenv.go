@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +18,9 @@ var (
 	// multiple config files cache: <file: <key: value>>
 	mu           sync.RWMutex
 	cachedConfig = make(map[string]map[string]interface{})
+	// cachedFileInfo holds the os.FileInfo observed at the last load of each
+	// config file, used to detect on-disk changes for auto-reload.
+	cachedFileInfo = make(map[string]os.FileInfo)
 )
 
 // DotEnv is a prioritized .env configuration registry.
@@ -50,15 +54,58 @@ var (
 //
 // DotEnv is safe for concurrent Get___() and Set() operations by multiple goroutines.
 type DotEnv struct {
+	// ConfigFile is the most recently registered config file -- the one
+	// Set, Save and Write act on. Use AddConfigFile or LoadConfigDir to
+	// layer additional files on top of it.
 	ConfigFile string
 
+	// configFiles holds every registered config file in registration
+	// order. Get walks it in reverse, so a later file overrides an earlier
+	// one for keys they both define. ConfigFile is always configFiles'
+	// last entry.
+	configFiles []string
+
 	// Separator is the symbol that separates the key-value pair.
 	// Default is `=`
 	Separator         string
 	prefix            string
 	allowEmptyEnvVars bool
+
+	// lookup is an optional user-provided resolver consulted first when
+	// expanding variable references in config values. See SetLookup.
+	lookup func(string) (string, bool)
+
+	// sources are additional Storage backends consulted, in registration
+	// order, after the env and config file. See AddSource.
+	sources []Storage
+
+	// autoReloadDisabled turns off the default behavior of transparently
+	// reloading the config file when it changes on disk. See SetAutoReload.
+	autoReloadDisabled bool
+
+	// onReload, if set, is called after the config file is automatically
+	// reloaded. See OnReload.
+	onReload func(changed, added, removed []string)
+
+	// replacer translates a config key into an environment variable name
+	// for the prefix-based naming convention. See SetEnvKeyReplacer.
+	replacer *strings.Replacer
+
+	// envBindings explicitly ties a config key to one or more environment
+	// variable names, checked before the prefix-based convention. See
+	// BindEnv.
+	envBindings map[string][]string
+
+	// subPrefix is prepended to every key passed to Get, Set, etc. by a
+	// view returned from Sub.
+	subPrefix string
 }
 
+// defaultEnvKeyReplacer maps dots and dashes to underscores, so a key like
+// "db.host" or "db-host" is looked up as DB_HOST (after the prefix and
+// upper-casing are applied).
+var defaultEnvKeyReplacer = strings.NewReplacer(".", "_", "-", "_")
+
 // global DotEnv instance
 var d *DotEnv
 
@@ -74,22 +121,21 @@ func Init(file ...string) *DotEnv {
 		configFile = file[0]
 	}
 
-	// TODO: support multiple .env files in one instance
-
 	if configFile == "" {
 		configFile = DefaultConfigFile
 	}
 
 	dotenv := &DotEnv{
-		ConfigFile: configFile,
-		Separator:  DefaultSeparator,
+		ConfigFile:  configFile,
+		configFiles: []string{configFile},
+		Separator:   DefaultSeparator,
 	}
 
 	return dotenv
 }
 
-// LoadConfig finds and read the config file.
-// returns os.ErrNotExist if config file does not exist
+// LoadConfig finds and reads every registered config file.
+// returns os.ErrNotExist if a config file does not exist
 func LoadConfig() error { return loadConfig() }
 
 func loadConfig() error {
@@ -99,19 +145,175 @@ func loadConfig() error {
 	return d.LoadConfig()
 }
 
-func (e *DotEnv) LoadConfig() (err error) {
-	if !checkFileExists(e.ConfigFile) {
+func (e *DotEnv) LoadConfig() error {
+	if len(e.configFiles) == 0 {
+		e.configFiles = []string{e.ConfigFile}
+	}
+
+	for _, file := range e.configFiles {
+		if err := e.loadConfigFile(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadConfigFile reads a single config file into the shared cache.
+func (e *DotEnv) loadConfigFile(file string) error {
+	if !checkFileExists(file) {
 		return os.ErrNotExist
 	}
 
-	c, err := readAndParseConfig(e.ConfigFile, e.Separator)
+	c, err := readAndParseConfig(file, e.Separator)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	cachedConfig[file] = c
+	if info, statErr := os.Stat(file); statErr == nil {
+		cachedFileInfo[file] = info
+	}
+	mu.Unlock()
+	return nil
+}
+
+// AddConfigFile registers an additional config file, loaded immediately,
+// that takes priority over every previously registered file for keys they
+// both define -- so the common `.env` + `.env.local` overlay pattern is
+// just:
+//
+//	e.AddConfigFile(".env")
+//	e.AddConfigFile(".env.local")
+func AddConfigFile(path string) error { return d.AddConfigFile(path) }
+
+func (e *DotEnv) AddConfigFile(path string) error {
+	if err := e.loadConfigFile(path); err != nil {
+		return err
+	}
+	e.configFiles = append(e.configFiles, path)
+	e.ConfigFile = path
+	return nil
+}
+
+// LoadConfigDir registers every file matching glob inside dir (e.g.
+// LoadConfigDir("conf.d", "*.env")), sorted by filename so the merge order
+// is deterministic. Later, alphabetically-greater files override earlier
+// ones, same as AddConfigFile.
+func LoadConfigDir(dir, glob string) error { return d.LoadConfigDir(dir, glob) }
+
+func (e *DotEnv) LoadConfigDir(dir, glob string) error {
+	matches, err := filepath.Glob(filepath.Join(dir, glob))
 	if err != nil {
 		return err
 	}
-	cachedConfig[e.ConfigFile] = c
+	sort.Strings(matches)
+
+	for _, path := range matches {
+		if err := e.AddConfigFile(path); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// SetAutoReload enables or disables automatically reloading the config file
+// when it changes on disk, checked on every Get call. Auto-reload is
+// enabled by default.
+func SetAutoReload(enabled bool) { d.SetAutoReload(enabled) }
+
+func (e *DotEnv) SetAutoReload(enabled bool) {
+	e.autoReloadDisabled = !enabled
+}
+
+// OnReload registers a callback invoked after the config file is
+// automatically reloaded, reporting which keys changed value, were added,
+// or were removed since the previous load.
+func OnReload(fn func(changed, added, removed []string)) { d.OnReload(fn) }
+
+func (e *DotEnv) OnReload(fn func(changed, added, removed []string)) {
+	e.onReload = fn
+}
+
+// reloadIfChanged stats every registered config file and, for any whose
+// mtime or size changed since the last load, reparses it and reports the
+// diff via OnReload.
+func (e *DotEnv) reloadIfChanged() {
+	if e.autoReloadDisabled {
+		return
+	}
+
+	for _, file := range e.configFiles {
+		e.reloadFileIfChanged(file)
+	}
+}
+
+func (e *DotEnv) reloadFileIfChanged(file string) {
+	if file == "" || !checkFileExists(file) {
+		return
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return
+	}
+
+	mu.RLock()
+	prev, known := cachedFileInfo[file]
+	mu.RUnlock()
+
+	if known && prev.ModTime().Equal(info.ModTime()) && prev.Size() == info.Size() {
+		return
+	}
+
+	before := snapshotConfig(file)
+	if err := e.loadConfigFile(file); err != nil {
+		return
+	}
+
+	if known && e.onReload != nil {
+		after := snapshotConfig(file)
+		changed, added, removed := diffConfig(before, after)
+		if len(changed)+len(added)+len(removed) > 0 {
+			e.onReload(changed, added, removed)
+		}
+	}
+}
+
+// snapshotConfig returns a shallow copy of the cached key/value pairs for
+// path, suitable for diffing against a later snapshot.
+func snapshotConfig(path string) map[string]interface{} {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	snap := make(map[string]interface{}, len(cachedConfig[path]))
+	for k, v := range cachedConfig[path] {
+		snap[k] = v
+	}
+	return snap
+}
+
+// diffConfig compares two config snapshots and reports which keys changed
+// value, were added, or were removed going from before to after. Values are
+// compared via cast.ToString rather than `!=`, since the cache may hold
+// non-comparable types (e.g. a slice or map set via Set), which would
+// otherwise panic.
+func diffConfig(before, after map[string]interface{}) (changed, added, removed []string) {
+	for k, v := range after {
+		if old, ok := before[k]; !ok {
+			added = append(added, k)
+		} else if cast.ToString(old) != cast.ToString(v) {
+			changed = append(changed, k)
+		}
+	}
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	return
+}
+
 // GetDotEnv returns the global DotEnv instance.
 func GetDotEnv() *DotEnv {
 	return d
@@ -142,6 +344,80 @@ func (e *DotEnv) addPrefix(key string) string {
 	return key
 }
 
+// SetEnvKeyReplacer sets the strings.Replacer used to turn a config key
+// into the environment variable name Get looks for, applied before the
+// prefix is added and the result is upper-cased. Defaults to replacing "."
+// and "-" with "_", so e.g. GetString("db.host") looks for <PREFIX>_DB_HOST.
+func SetEnvKeyReplacer(r *strings.Replacer) { d.SetEnvKeyReplacer(r) }
+
+func (e *DotEnv) SetEnvKeyReplacer(r *strings.Replacer) {
+	e.replacer = r
+}
+
+func (e *DotEnv) envKeyReplacer() *strings.Replacer {
+	if e.replacer != nil {
+		return e.replacer
+	}
+	return defaultEnvKeyReplacer
+}
+
+// BindEnv explicitly ties key to one or more environment variable names,
+// checked in order before the prefix-based naming convention applied by
+// SetEnvKeyReplacer. This covers keys whose env var name doesn't follow
+// that convention, e.g. binding "db.host" to the legacy "DATABASE_HOST".
+func BindEnv(key string, envVars ...string) { d.BindEnv(key, envVars...) }
+
+func (e *DotEnv) BindEnv(key string, envVars ...string) {
+	if e.envBindings == nil {
+		e.envBindings = make(map[string][]string)
+	}
+	e.envBindings[strings.ToUpper(key)] = envVars
+}
+
+// lookupEnvKey resolves key to an environment variable, checking any
+// envVars bound via BindEnv first, then the prefix-based naming convention.
+func (e *DotEnv) lookupEnvKey(key string) (string, bool) {
+	if vars, ok := e.envBindings[strings.ToUpper(key)]; ok {
+		for _, name := range vars {
+			if val, ok := os.LookupEnv(name); ok {
+				return val, true
+			}
+		}
+	}
+
+	envKey := strings.ToUpper(e.addPrefix(e.envKeyReplacer().Replace(key)))
+	return os.LookupEnv(envKey)
+}
+
+// Sub returns a DotEnv view scoped to keys beginning with prefix + "_". Get
+// calls made through the returned instance automatically have that prefix
+// prepended, so cfg.Sub("DB").GetString("HOST") behaves exactly like
+// cfg.GetString("DB_HOST"). The sub-view shares the parent's cache, config
+// file, sources and lookup function, and keeps honoring the parent's
+// env-var prefix set via SetPrefix.
+func Sub(prefix string) *DotEnv { return d.Sub(prefix) }
+
+func (e *DotEnv) Sub(prefix string) *DotEnv {
+	sub := *e
+	sub.subPrefix = e.subPrefix + strings.ToUpper(prefix) + "_"
+	return &sub
+}
+
+// BindStruct unmarshals just the subtree rooted at prefix (see Sub) into
+// out, so callers can write cfg.BindStruct("LOG", &logCfg) instead of
+// allocating a whole new DotEnv or duplicating `env` tags per field.
+//
+// Because it unmarshals through Sub, out's `env` tags must be relative to
+// prefix, not absolute: a field meant to read the LOG_LEVEL key is tagged
+// `env:"LEVEL"`, not `env:"LOG_LEVEL"` -- Sub already prepends "LOG_" to
+// every key it resolves, so an absolute tag would resolve LOG_LOG_LEVEL
+// and silently come back empty.
+func BindStruct(prefix string, out interface{}) error { return d.BindStruct(prefix, out) }
+
+func (e *DotEnv) BindStruct(prefix string, out interface{}) error {
+	return e.Sub(prefix).Unmarshal(out)
+}
+
 // AllowEmptyEnv tells Dotenv to consider set, but empty environment variables
 // as valid values instead of falling back to config value.
 // This is set to true by default.
@@ -153,6 +429,8 @@ func (e *DotEnv) AllowEmptyEnvVars(allowEmptyEnvVars bool) {
 
 // SetConfigFile explicitly defines the path, name and extension of the config file.
 // Dotenv will use this and not check .env from the current directory.
+// It resets any additional files registered via AddConfigFile/LoadConfigDir
+// back to this single file.
 func SetConfigFile(configFile string) {
 	if d != nil {
 		d.SetConfigFile(configFile)
@@ -163,41 +441,235 @@ func SetConfigFile(configFile string) {
 
 func (e *DotEnv) SetConfigFile(configFile string) {
 	e.ConfigFile = configFile
+	e.configFiles = []string{configFile}
+}
+
+// SetLookup registers a resolver function consulted first when expanding
+// `$VAR`/`${VAR}` references found in config values (see Get). When lookup
+// returns false, resolution falls back to other keys already parsed from
+// the registered config files, then to os.LookupEnv.
+func SetLookup(lookup func(string) (string, bool)) { d.SetLookup(lookup) }
+
+func (e *DotEnv) SetLookup(lookup func(string) (string, bool)) {
+	e.lookup = lookup
 }
 
 // Get can retrieve any value given the key to use.
 // Get is case-insensitive for a key.
 // Dotenv will check in the following order:
-// configOverride cache, env, key/value store, config file
+// bound/conventional env var, config file, key/value store
+//
+// A matching environment variable always wins over the config file, even
+// for keys the config file also defines; this lets an env var override a
+// file-provided default without needing BindEnv.
 //
 // Get returns an interface. For a specific value use one of the Get___ methods e.g. GetBool(key) for a boolean value
 func Get(key string) interface{} { return d.Get(key) }
 
 func (e *DotEnv) Get(key string) interface{} {
+	e.reloadIfChanged()
+
 	if key != "" {
-		key = e.addPrefix(key)
-		key = strings.ToUpper(key)
+		key = e.subPrefix + key
 
-		if val, ok := os.LookupEnv(key); ok {
-			if val == "" && !e.allowEmptyEnvVars {
+		// env vars take precedence over the config file, even when the key
+		// is also defined there (see the doc comment above).
+		if val, ok := e.lookupEnvKey(key); ok {
+			if val != "" || e.allowEmptyEnvVars {
 				return val
 			}
 		}
 
-		val, ok, err := GetFromFile(e.ConfigFile, key, e.Separator)
-		if err == nil && ok {
-			return val
+		key = e.addPrefix(key)
+		key = strings.ToUpper(key)
+
+		if val, ok := e.getFromConfigFiles(key); ok {
+			return e.expandValue(key, val)
 		}
 
-		// get from config file
-		envFromFile, _, _ := getConfigValueWithKey(e.ConfigFile, key, e.Separator)
+		if val, ok := e.getFromSources(key); ok {
+			return val
+		}
 
-		return envFromFile
+		return os.Getenv(key)
 	}
 
 	return ""
 }
 
+// expandValue expands POSIX-style variable references (`$VAR`, `${VAR}`,
+// `${VAR:-default}`, `${VAR-default}`, `${VAR:?err}`, and the `$$` escape)
+// found in string values read from the config file. Non-string values are
+// returned unchanged. If expansion fails, e.g. a cycle is detected or a
+// `:?` reference is unset, the original, unexpanded value is returned; use
+// ExpandEnv directly if the error is needed.
+func (e *DotEnv) expandValue(key string, val interface{}) interface{} {
+	s, ok := val.(string)
+	if !ok {
+		return val
+	}
+
+	expanded, err := e.expand(key, s, nil)
+	if err != nil {
+		return val
+	}
+	return expanded
+}
+
+// ExpandEnv expands POSIX-style variable references in value the same way
+// Get does, using key to seed cycle detection, and returns an error if a
+// cycle is found or a `${VAR:?err}` reference is unset or empty.
+func (e *DotEnv) ExpandEnv(key, value string) (string, error) {
+	return e.expand(key, value, nil)
+}
+
+func (e *DotEnv) expand(key, value string, visiting map[string]bool) (string, error) {
+	if visiting == nil {
+		visiting = make(map[string]bool)
+	}
+	if visiting[key] {
+		return "", fmt.Errorf("dotenv: circular reference detected while expanding %q", key)
+	}
+	visiting[key] = true
+	defer delete(visiting, key)
+
+	var buf strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c != '$' || i == len(value)-1 {
+			buf.WriteByte(c)
+			continue
+		}
+
+		next := value[i+1]
+		switch {
+		case next == '$':
+			buf.WriteByte('$')
+			i++
+		case next == '{':
+			closeIdx := strings.IndexByte(value[i+2:], '}')
+			if closeIdx < 0 {
+				buf.WriteByte(c)
+				continue
+			}
+			expr := value[i+2 : i+2+closeIdx]
+			resolved, err := e.resolveExpr(expr, visiting)
+			if err != nil {
+				return "", err
+			}
+			buf.WriteString(resolved)
+			i += 2 + closeIdx
+		case isVarNameStart(next):
+			j := i + 1
+			for j < len(value) && isVarNameChar(value[j]) {
+				j++
+			}
+			name := value[i+1 : j]
+			val, _ := e.lookupVar(name)
+			resolved, err := e.expand(name, val, visiting)
+			if err != nil {
+				return "", err
+			}
+			buf.WriteString(resolved)
+			i = j - 1
+		default:
+			buf.WriteByte(c)
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// resolveExpr resolves the contents of a `${...}` expression: a variable
+// name optionally followed by a `:-`, `-`, `:?` or `?` operator and its
+// default value or error message. `:?` (POSIX "error if unset or empty")
+// and `?` (POSIX "error if unset") are distinct: `${VAR?err}` only errors
+// when VAR is unset, whereas `${VAR:?err}` also errors when VAR is set but
+// empty.
+func (e *DotEnv) resolveExpr(expr string, visiting map[string]bool) (string, error) {
+	name, op, rest := splitVarExpr(expr)
+
+	val, ok := e.lookupVar(name)
+	switch op {
+	case ":-":
+		if !ok || val == "" {
+			return e.expand(name, rest, visiting)
+		}
+	case "-":
+		if !ok {
+			return e.expand(name, rest, visiting)
+		}
+	case ":?":
+		if !ok || val == "" {
+			msg := rest
+			if msg == "" {
+				msg = "not set"
+			}
+			return "", fmt.Errorf("dotenv: %s: %s", name, msg)
+		}
+	case "?":
+		if !ok {
+			msg := rest
+			if msg == "" {
+				msg = "not set"
+			}
+			return "", fmt.Errorf("dotenv: %s: %s", name, msg)
+		}
+	}
+
+	if !ok {
+		return "", nil
+	}
+	return e.expand(name, val, visiting)
+}
+
+// lookupVar resolves a variable reference in the order: the user-registered
+// SetLookup function, other keys already parsed from the registered config
+// files (honoring their merge order), then os.LookupEnv (honoring the
+// configured prefix).
+func (e *DotEnv) lookupVar(name string) (string, bool) {
+	if e.lookup != nil {
+		if val, ok := e.lookup(name); ok {
+			return val, true
+		}
+	}
+
+	if cached, ok := e.getFromConfigFiles(strings.ToUpper(name)); ok {
+		return cast.ToString(cached), true
+	}
+
+	return os.LookupEnv(strings.ToUpper(e.addPrefix(name)))
+}
+
+// splitVarExpr splits a `${...}` expression body into its variable name and,
+// if present, its `:-`/`-`/`:?`/`?` operator and the remainder following it.
+func splitVarExpr(expr string) (name, op, rest string) {
+	for i := 0; i < len(expr); i++ {
+		switch expr[i] {
+		case ':':
+			if i+1 < len(expr) && expr[i+1] == '-' {
+				return expr[:i], ":-", expr[i+2:]
+			}
+			if i+1 < len(expr) && expr[i+1] == '?' {
+				return expr[:i], ":?", expr[i+2:]
+			}
+		case '-':
+			return expr[:i], "-", expr[i+1:]
+		case '?':
+			return expr[:i], "?", expr[i+1:]
+		}
+	}
+	return expr, "", ""
+}
+
+func isVarNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isVarNameChar(c byte) bool {
+	return isVarNameStart(c) || (c >= '0' && c <= '9')
+}
+
 // GetString returns the value associated with the key as a string.
 func GetString(key string) string { return d.GetString(key) }
 
@@ -334,8 +806,7 @@ func (e *DotEnv) IsSet(key string) bool {
 func LookUp(key string) (interface{}, bool) { return d.LookUp(key) }
 
 func (e *DotEnv) LookUp(key string) (interface{}, bool) {
-	env, isSet, _ := GetFromFile(e.ConfigFile, key, e.Separator)
-	return env, isSet
+	return e.getFromConfigFiles(key)
 }
 
 // Set sets or update env variable
@@ -344,6 +815,7 @@ func (e *DotEnv) LookUp(key string) (interface{}, bool) {
 func Set(key, value string) { d.Set(key, value) }
 
 func (e *DotEnv) Set(key string, value interface{}) {
+	key = e.subPrefix + key
 	key = e.addPrefix(key)
 	key = strings.ToUpper(key)
 
@@ -414,15 +886,58 @@ func GetFromFile(filePath, key, separator string) (interface{}, bool, error) {
 	return "", false, nil
 }
 
-func getConfigValueWithKey(configFile, key, separator string) (env interface{}, exists bool, err error) {
-	// first get os env var
-	env = os.Getenv(key)
+// getFromConfigFiles walks the registered config files in reverse
+// registration order -- so a later file (e.g. .env.local) overrides an
+// earlier one (e.g. .env) -- and returns the value from the first file that
+// defines key.
+func (e *DotEnv) getFromConfigFiles(key string) (interface{}, bool) {
+	for i := len(e.configFiles) - 1; i >= 0; i-- {
+		if val, ok, err := GetFromFile(e.configFiles[i], key, e.Separator); err == nil && ok {
+			return val, true
+		}
+	}
+	return nil, false
+}
+
+// MergedKeys returns every key known across all registered config files
+// after merging, i.e. the same keys Get can resolve from a file.
+func MergedKeys() []string { return d.MergedKeys() }
+
+func (e *DotEnv) MergedKeys() []string {
+	seen := make(map[string]bool)
+	var keys []string
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, file := range e.configFiles {
+		for key := range cachedConfig[file] {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// Source returns the path of the registered config file that currently
+// supplies key's value, honoring the same override order as Get, or "" if
+// no registered config file defines it.
+func Source(key string) string { return d.Source(key) }
+
+func (e *DotEnv) Source(key string) string {
+	key = strings.ToUpper(e.addPrefix(e.subPrefix + key))
 
-	if env == "" {
-		// Find config variable in config file
-		env, exists, err = GetFromFile(configFile, key, separator)
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for i := len(e.configFiles) - 1; i >= 0; i-- {
+		if _, ok := cachedConfig[e.configFiles[i]][key]; ok {
+			return e.configFiles[i]
+		}
 	}
-	return
+	return ""
 }
 
 func writeConfig(cfgFile, data string) error {
@@ -1,6 +1,9 @@
 package dotenv_test
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -97,6 +100,205 @@ func TestLoadExportedEnv(t *testing.T) {
 	}
 }
 
+func TestGetExpandsVariables(t *testing.T) {
+	envFileName := "fixtures/expand.env"
+	expectedValues := map[string]string{
+		"DB_URL":   "localhost:5432/app",
+		"GREETING": "hello $world",
+	}
+
+	testReadEnvAndCompare(t, envFileName, expectedValues)
+}
+
+func TestGetExpandLookupAndRequired(t *testing.T) {
+	envFileName := "fixtures/expand.env"
+
+	de := dotenv.New()
+	de.SetConfigFile(envFileName)
+	require.NoError(t, de.LoadConfig())
+
+	de.SetLookup(func(key string) (string, bool) {
+		if key == "DB_HOST" {
+			return "db.internal", true
+		}
+		return "", false
+	})
+
+	require.Equal(t, "db.internal:5432/app", de.Get("DB_URL"))
+
+	_, err := de.ExpandEnv("REQUIRED", "${MISSING:?must be set}")
+	require.Error(t, err)
+
+	de.SetLookup(func(key string) (string, bool) {
+		// every key expands to a reference to itself, forcing a cycle
+		return "${" + key + "}", true
+	})
+	_, err = de.ExpandEnv("A", "${A}")
+	require.Error(t, err)
+}
+
+// memStorage is a trivial in-memory dotenv.Storage used to exercise AddSource.
+type memStorage struct{ data map[string]interface{} }
+
+func (m *memStorage) Get(key string) (interface{}, bool, error) {
+	val, ok := m.data[key]
+	return val, ok, nil
+}
+func (m *memStorage) Set(key string, value interface{}) error {
+	m.data[key] = value
+	return nil
+}
+func (m *memStorage) Keys() ([]string, error) {
+	keys := make([]string, 0, len(m.data))
+	for k := range m.data {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+func (m *memStorage) Load() error { return nil }
+func (m *memStorage) Save() error { return nil }
+
+func TestAddSourceFallsBackWhenKeyMissingFromFile(t *testing.T) {
+	de := dotenv.New()
+	de.SetConfigFile("fixtures/plain.env")
+	require.NoError(t, de.LoadConfig())
+
+	require.NoError(t, de.AddSource(&memStorage{data: map[string]interface{}{"FROM_STORE": "storeval"}}))
+
+	require.Equal(t, "storeval", de.Get("FROM_STORE"))
+}
+
+func TestAutoReloadPicksUpFileChanges(t *testing.T) {
+	envFileName := filepath.Join(t.TempDir(), "reload.env")
+	require.NoError(t, os.WriteFile(envFileName, []byte("OPTION_A=1\n"), 0644))
+
+	de := dotenv.New()
+	de.SetConfigFile(envFileName)
+	require.NoError(t, de.LoadConfig())
+	require.Equal(t, "1", de.GetString("OPTION_A"))
+
+	var changed, added, removed []string
+	de.OnReload(func(c, a, r []string) {
+		changed, added, removed = c, a, r
+	})
+
+	// ensure the new mtime is observably different on filesystems with
+	// coarse mtime resolution
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, os.WriteFile(envFileName, []byte("OPTION_A=2\nOPTION_B=new\n"), 0644))
+
+	require.Equal(t, "2", de.GetString("OPTION_A"))
+	require.Equal(t, "new", de.GetString("OPTION_B"))
+	require.ElementsMatch(t, []string{"OPTION_A"}, changed)
+	require.ElementsMatch(t, []string{"OPTION_B"}, added)
+	require.Empty(t, removed)
+}
+
+func TestEnvOverrideForUnconfiguredKeys(t *testing.T) {
+	de := dotenv.New()
+	de.SetConfigFile("fixtures/plain.env")
+	require.NoError(t, de.LoadConfig())
+	de.SetPrefix("app")
+
+	t.Setenv("APP_DB_HOST", "from-env")
+	require.Equal(t, "from-env", de.GetString("db.host"))
+
+	de.SetEnvKeyReplacer(strings.NewReplacer(".", "__"))
+	t.Setenv("APP_DB__PORT", "5432")
+	require.Equal(t, "5432", de.GetString("db.port"))
+
+	de.BindEnv("db.host", "LEGACY_DB_HOST", "APP_DB_HOST")
+	t.Setenv("LEGACY_DB_HOST", "legacy-wins")
+	require.Equal(t, "legacy-wins", de.GetString("db.host"))
+}
+
+func TestSubScopesToPrefixedKeys(t *testing.T) {
+	de := dotenv.New()
+	de.SetConfigFile("fixtures/plain.env")
+	require.NoError(t, de.LoadConfig())
+
+	de.Set("DB_HOST", "localhost")
+	de.Set("DB_PORT", "5432")
+
+	db := de.Sub("DB")
+	require.Equal(t, "localhost", db.GetString("HOST"))
+	require.Equal(t, "5432", db.GetString("PORT"))
+
+	// the sub-view shares the parent's cache
+	de.Set("DB_HOST", "db.internal")
+	require.Equal(t, "db.internal", db.GetString("HOST"))
+}
+
+func TestLayeredConfigFilesOverrideInRegistrationOrder(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, ".env")
+	overlay := filepath.Join(dir, ".env.local")
+
+	require.NoError(t, os.WriteFile(base, []byte("OPTION_A=1\nOPTION_B=2\n"), 0644))
+	require.NoError(t, os.WriteFile(overlay, []byte("OPTION_B=local\n"), 0644))
+
+	de := dotenv.New()
+	require.NoError(t, de.AddConfigFile(base))
+	require.NoError(t, de.AddConfigFile(overlay))
+
+	require.Equal(t, "1", de.GetString("OPTION_A"))
+	require.Equal(t, "local", de.GetString("OPTION_B"))
+
+	require.ElementsMatch(t, []string{"OPTION_A", "OPTION_B"}, de.MergedKeys())
+	require.Equal(t, base, de.Source("OPTION_A"))
+	require.Equal(t, overlay, de.Source("OPTION_B"))
+}
+
+func TestBindStructUsesTagsRelativeToPrefix(t *testing.T) {
+	// unlike Unmarshal (see TestUnMarshal), BindStruct unmarshals through
+	// Sub, so tags must be relative to the bound prefix: "LEVEL", not
+	// "LOG_LEVEL" -- Sub already strips/prepends "LOG_" for us.
+	type Log struct {
+		Level   string `env:"LEVEL" default:"info"`
+		Channel string `env:"CHANNEL" default:"stdout"`
+		Path    string `env:"PATH" default:"/var/log/app.log"`
+	}
+
+	de := dotenv.New()
+	de.SetConfigFile("fixtures/test.env")
+	require.NoError(t, de.LoadConfig())
+	de.SetPrefix("APP")
+
+	var log Log
+	require.NoError(t, de.BindStruct("LOG", &log))
+
+	require.Equal(t, Log{
+		Level:   "debug",
+		Channel: "stack",
+		Path:    "storage/logs/app.log",
+	}, log)
+}
+
+func TestAutoReloadDiffDoesNotPanicOnNonComparableValues(t *testing.T) {
+	envFileName := filepath.Join(t.TempDir(), "reload2.env")
+	require.NoError(t, os.WriteFile(envFileName, []byte("OPTION_A=1\n"), 0644))
+
+	de := dotenv.New()
+	de.SetConfigFile(envFileName)
+	require.NoError(t, de.LoadConfig())
+
+	// diffConfig only runs when a reload callback is registered, so register
+	// one to actually exercise the comparison this test is meant to cover.
+	de.OnReload(func(_, _, _ []string) {})
+
+	// simulates a prior Set(key, sliceOrMapValue) call leaving a
+	// non-comparable value in the cache; diffing it against the reloaded
+	// (string) value must not panic.
+	de.Set("OPTION_A", []string{"not", "comparable"})
+
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, os.WriteFile(envFileName, []byte("OPTION_A=2\n"), 0644))
+
+	require.NotPanics(t, func() {
+		de.GetString("OPTION_A")
+	})
+}
+
 func TestUnMarshal(t *testing.T) {
 	type DB struct {
 		Host     string `env:"DB_HOST" default:"localhost"`
@@ -0,0 +1,54 @@
+package dotenv
+
+// Storage is implemented by pluggable configuration backends. Built-in and
+// external implementations (see the storage/ directory, e.g. storage/etcd,
+// storage/consul, storage/vault) let DotEnv read configuration from systems
+// other than a local .env file. DotEnv only reads from a registered source;
+// its Set, Save and Write act on the config file cache, so writing to a
+// backend goes through the backend's own Set and Save.
+type Storage interface {
+	// Get returns the value stored under key, and whether it was found.
+	Get(key string) (interface{}, bool, error)
+
+	// Set stores value under key in the backend.
+	Set(key string, value interface{}) error
+
+	// Keys returns every key currently known to the backend.
+	Keys() ([]string, error)
+
+	// Load (re)reads the backend's data into memory. DotEnv calls it once,
+	// when the source is registered via AddSource. The etcd and Consul
+	// backends refresh their in-memory data from a background watch without
+	// going through Load.
+	Load() error
+
+	// Save persists any pending local changes made via Set back to the
+	// backend. Backends that are read-only may return nil without doing
+	// anything.
+	Save() error
+}
+
+// AddSource registers an additional Storage backend that Get consults after
+// environment variables and the config file, in registration order -- the
+// first registered source to have the key wins over later ones. Load is
+// called on source immediately so it is ready to read from.
+func AddSource(source Storage) error { return d.AddSource(source) }
+
+func (e *DotEnv) AddSource(source Storage) error {
+	if err := source.Load(); err != nil {
+		return err
+	}
+	e.sources = append(e.sources, source)
+	return nil
+}
+
+// getFromSources walks the registered sources in registration order and
+// returns the value from the first one that has key.
+func (e *DotEnv) getFromSources(key string) (interface{}, bool) {
+	for _, src := range e.sources {
+		if val, ok, err := src.Get(key); err == nil && ok {
+			return val, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,168 @@
+// Package consul is a Storage backend that reads and writes keys under a
+// prefix in Consul's KV store, and keeps them fresh via a long-poll watch.
+package consul
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+)
+
+// watchRetryDelay bounds how fast watch retries a failed blocking query, so
+// an unreachable Consul agent doesn't turn into a tight, zero-delay spin
+// loop hammering the API.
+const watchRetryDelay = 2 * time.Second
+
+// Config configures a Store.
+type Config struct {
+	// Address is the Consul HTTP API address, e.g. "localhost:8500".
+	Address string
+
+	// Token is the ACL token used for requests, if Consul has ACLs enabled.
+	Token string
+
+	// Prefix is prepended to every key in the KV store, e.g. "myapp/config/".
+	Prefix string
+}
+
+// Store is a Storage backed by a prefix in Consul's KV store.
+type Store struct {
+	cfg    Config
+	client *api.Client
+
+	mu      sync.RWMutex
+	data    map[string]string
+	index   uint64
+	closing chan struct{}
+}
+
+// New connects to the Consul agent described by cfg and starts a background
+// long-poll watch on Prefix.
+func New(cfg Config) (*Store, error) {
+	client, err := api.NewClient(&api.Config{Address: cfg.Address, Token: cfg.Token})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{cfg: cfg, client: client, closing: make(chan struct{})}
+	go s.watch()
+	return s, nil
+}
+
+// Get returns the value for key, and whether it was found.
+func (s *Store) Get(key string) (interface{}, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	val, ok := s.data[normalizeKey(key)]
+	return val, ok, nil
+}
+
+// Set writes value to Consul's KV store under Prefix+key.
+func (s *Store) Set(key string, value interface{}) error {
+	pair := &api.KVPair{Key: s.cfg.Prefix + key, Value: []byte(toString(value))}
+	if _, err := s.client.KV().Put(pair, nil); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if s.data == nil {
+		s.data = make(map[string]string)
+	}
+	s.data[normalizeKey(key)] = toString(value)
+	s.mu.Unlock()
+	return nil
+}
+
+// Keys returns every key currently cached under Prefix.
+func (s *Store) Keys() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.data))
+	for k := range s.data {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// Load fetches every key under Prefix from Consul into memory.
+func (s *Store) Load() error {
+	pairs, meta, err := s.client.KV().List(s.cfg.Prefix, nil)
+	if err != nil {
+		return err
+	}
+
+	data := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		data[normalizeKey(strings.TrimPrefix(pair.Key, s.cfg.Prefix))] = string(pair.Value)
+	}
+
+	s.mu.Lock()
+	s.data = data
+	s.index = meta.LastIndex
+	s.mu.Unlock()
+	return nil
+}
+
+// Save is a no-op: Set already writes through to Consul immediately.
+func (s *Store) Save() error { return nil }
+
+// watch long-polls Consul's blocking query API for changes under Prefix and
+// refreshes the in-memory cache whenever the KV index advances.
+func (s *Store) watch() {
+	for {
+		select {
+		case <-s.closing:
+			return
+		default:
+		}
+
+		s.mu.RLock()
+		waitIndex := s.index
+		s.mu.RUnlock()
+
+		pairs, meta, err := s.client.KV().List(s.cfg.Prefix, &api.QueryOptions{WaitIndex: waitIndex})
+		if err != nil {
+			// back off before retrying so an unreachable agent doesn't turn
+			// this into a zero-delay spin loop
+			time.Sleep(watchRetryDelay)
+			continue
+		}
+		if meta.LastIndex == waitIndex {
+			continue
+		}
+
+		data := make(map[string]string, len(pairs))
+		for _, pair := range pairs {
+			data[normalizeKey(strings.TrimPrefix(pair.Key, s.cfg.Prefix))] = string(pair.Value)
+		}
+
+		s.mu.Lock()
+		s.data = data
+		s.index = meta.LastIndex
+		s.mu.Unlock()
+	}
+}
+
+// Close stops the background watch.
+func (s *Store) Close() error {
+	close(s.closing)
+	return nil
+}
+
+func toString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// normalizeKey upper-cases key so it matches the case DotEnv.Get looks keys
+// up with, regardless of how it's cased in Consul's KV store.
+func normalizeKey(key string) string {
+	return strings.ToUpper(key)
+}
@@ -0,0 +1,176 @@
+// Package etcd is a Storage backend that reads and writes keys under a
+// prefix in an etcd v3 cluster, and can watch that prefix for changes.
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// Config configures a Store.
+type Config struct {
+	// Endpoints is the list of etcd cluster members, e.g. []string{"localhost:2379"}.
+	Endpoints []string
+
+	// Prefix is prepended to every key, e.g. "/myapp/". Keys are stored as
+	// Prefix+KEY.
+	Prefix string
+
+	// DialTimeout bounds how long New waits to connect. Defaults to 5s.
+	DialTimeout time.Duration
+
+	// Username/Password enable client-side auth, if the cluster requires it.
+	Username string
+	Password string
+}
+
+// Store is a Storage backed by a prefix in an etcd v3 cluster.
+type Store struct {
+	cfg    Config
+	client *clientv3.Client
+
+	mu   sync.RWMutex
+	data map[string]string
+
+	cancelWatch context.CancelFunc
+}
+
+// New connects to the etcd cluster described by cfg.
+func New(cfg Config) (*Store, error) {
+	if cfg.DialTimeout == 0 {
+		cfg.DialTimeout = 5 * time.Second
+	}
+
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   cfg.Endpoints,
+		DialTimeout: cfg.DialTimeout,
+		Username:    cfg.Username,
+		Password:    cfg.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{cfg: cfg, client: client}
+	s.watch()
+	return s, nil
+}
+
+func (s *Store) key(key string) string {
+	return s.cfg.Prefix + key
+}
+
+// Get returns the value for key, and whether it was found.
+func (s *Store) Get(key string) (interface{}, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	val, ok := s.data[normalizeKey(key)]
+	return val, ok, nil
+}
+
+// Set writes value to the cluster under Prefix+key.
+func (s *Store) Set(key string, value interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.DialTimeout)
+	defer cancel()
+
+	if _, err := s.client.Put(ctx, s.key(key), toString(value)); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if s.data == nil {
+		s.data = make(map[string]string)
+	}
+	s.data[normalizeKey(key)] = toString(value)
+	s.mu.Unlock()
+	return nil
+}
+
+// Keys returns every key currently cached under Prefix.
+func (s *Store) Keys() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.data))
+	for k := range s.data {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// Load fetches every key under Prefix from the cluster into memory.
+func (s *Store) Load() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.DialTimeout)
+	defer cancel()
+
+	resp, err := s.client.Get(ctx, s.cfg.Prefix, clientv3.WithPrefix())
+	if err != nil {
+		return err
+	}
+
+	data := make(map[string]string, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		data[normalizeKey(strings.TrimPrefix(string(kv.Key), s.cfg.Prefix))] = string(kv.Value)
+	}
+
+	s.mu.Lock()
+	s.data = data
+	s.mu.Unlock()
+	return nil
+}
+
+// Save is a no-op: Set already writes through to etcd immediately.
+func (s *Store) Save() error { return nil }
+
+// watch keeps the in-memory cache in sync with changes made to Prefix by
+// other clients.
+func (s *Store) watch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancelWatch = cancel
+
+	ch := s.client.Watch(ctx, s.cfg.Prefix, clientv3.WithPrefix())
+	go func() {
+		for resp := range ch {
+			s.mu.Lock()
+			if s.data == nil {
+				s.data = make(map[string]string)
+			}
+			for _, ev := range resp.Events {
+				key := normalizeKey(strings.TrimPrefix(string(ev.Kv.Key), s.cfg.Prefix))
+				if ev.Type == clientv3.EventTypeDelete {
+					delete(s.data, key)
+					continue
+				}
+				s.data[key] = string(ev.Kv.Value)
+			}
+			s.mu.Unlock()
+		}
+	}()
+}
+
+// Close stops watching Prefix and closes the underlying etcd client.
+func (s *Store) Close() error {
+	if s.cancelWatch != nil {
+		s.cancelWatch()
+	}
+	return s.client.Close()
+}
+
+func toString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// normalizeKey upper-cases key so it matches the case DotEnv.Get looks keys
+// up with, regardless of how it's cased in etcd.
+func normalizeKey(key string) string {
+	return strings.ToUpper(key)
+}
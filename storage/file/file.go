@@ -0,0 +1,128 @@
+// Package file is a Storage backend that reads and writes a key/value pair
+// per line from a local .env-formatted file, the same format DotEnv's
+// built-in config file cache understands. DotEnv reads its own config files
+// through that built-in cache, not through this package; a Store is only
+// consulted once it is registered with AddSource.
+package file
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Store is a Storage backed by a single .env file on disk.
+type Store struct {
+	// Path is the file to read from and write to.
+	Path string
+
+	// Separator is the symbol that separates the key-value pair.
+	// Defaults to "=" when empty.
+	Separator string
+
+	mu   sync.RWMutex
+	data map[string]interface{}
+}
+
+// New returns a Store reading from and writing to path.
+func New(path string) *Store {
+	return &Store{Path: path, Separator: "="}
+}
+
+func (s *Store) separator() string {
+	if s.Separator == "" {
+		return "="
+	}
+	return s.Separator
+}
+
+// Get returns the value for key, and whether it was found.
+func (s *Store) Get(key string) (interface{}, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	val, ok := s.data[key]
+	return val, ok, nil
+}
+
+// Set stores value under key in memory; call Save to persist it to Path.
+func (s *Store) Set(key string, value interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data == nil {
+		s.data = make(map[string]interface{})
+	}
+	s.data[key] = value
+	return nil
+}
+
+// Keys returns every key currently loaded from the file.
+func (s *Store) Keys() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.data))
+	for k := range s.data {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// Load reads Path and replaces the in-memory cache with its contents.
+func (s *Store) Load() error {
+	f, err := os.Open(s.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			s.mu.Lock()
+			s.data = make(map[string]interface{})
+			s.mu.Unlock()
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	data := make(map[string]interface{})
+	sep := s.separator()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		parts := strings.SplitN(line, sep, 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(parts[0])
+		value := strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+		data[strings.ToUpper(key)] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.data = data
+	s.mu.Unlock()
+	return nil
+}
+
+// Save writes the in-memory cache back to Path.
+func (s *Store) Save() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var b strings.Builder
+	for key, value := range s.data {
+		fmt.Fprintf(&b, "%s%s%v\n", key, s.separator(), value)
+	}
+
+	return os.WriteFile(s.Path, []byte(b.String()), 0666)
+}
@@ -0,0 +1,143 @@
+// Package vault is a read/write Storage backend for a HashiCorp Vault KV v2
+// secret, authenticating with either a static token or AppRole credentials.
+package vault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+// Config configures a Store.
+type Config struct {
+	// Address is the Vault server address, e.g. "https://vault.internal:8200".
+	Address string
+
+	// MountPath is the KV v2 secrets engine mount, e.g. "secret".
+	MountPath string
+
+	// SecretPath is the path of the secret within MountPath, e.g. "myapp/config".
+	SecretPath string
+
+	// Token authenticates directly with a Vault token. Leave empty to use
+	// AppRole auth via RoleID/SecretID instead.
+	Token string
+
+	// RoleID and SecretID authenticate via the AppRole auth method when
+	// Token is empty.
+	RoleID   string
+	SecretID string
+}
+
+// Store is a Storage backed by a single KV v2 secret in Vault.
+type Store struct {
+	cfg    Config
+	client *vaultapi.Client
+
+	mu   sync.RWMutex
+	data map[string]interface{}
+}
+
+// New creates an authenticated client for the Vault server described by cfg.
+func New(cfg Config) (*Store, error) {
+	client, err := vaultapi.NewClient(&vaultapi.Config{Address: cfg.Address})
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Token != "" {
+		client.SetToken(cfg.Token)
+	} else {
+		if err := loginAppRole(client, cfg.RoleID, cfg.SecretID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Store{cfg: cfg, client: client}, nil
+}
+
+func loginAppRole(client *vaultapi.Client, roleID, secretID string) error {
+	secret, err := client.Logical().Write("auth/approle/login", map[string]interface{}{
+		"role_id":   roleID,
+		"secret_id": secretID,
+	})
+	if err != nil {
+		return err
+	}
+	if secret == nil || secret.Auth == nil {
+		return fmt.Errorf("vault: approle login returned no auth info")
+	}
+	client.SetToken(secret.Auth.ClientToken)
+	return nil
+}
+
+// Get returns the value for key, and whether it was found.
+func (s *Store) Get(key string) (interface{}, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	val, ok := s.data[normalizeKey(key)]
+	return val, ok, nil
+}
+
+// Set stores value under key in memory; call Save to write it back to Vault.
+func (s *Store) Set(key string, value interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data == nil {
+		s.data = make(map[string]interface{})
+	}
+	s.data[normalizeKey(key)] = value
+	return nil
+}
+
+// Keys returns every key currently loaded from the secret.
+func (s *Store) Keys() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.data))
+	for k := range s.data {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// Load reads the secret at MountPath/SecretPath and replaces the in-memory
+// cache with its data.
+func (s *Store) Load() error {
+	secret, err := s.client.KVv2(s.cfg.MountPath).Get(context.TODO(), s.cfg.SecretPath)
+	if err != nil {
+		return err
+	}
+
+	data := make(map[string]interface{}, len(secret.Data))
+	for k, v := range secret.Data {
+		data[normalizeKey(k)] = v
+	}
+
+	s.mu.Lock()
+	s.data = data
+	s.mu.Unlock()
+	return nil
+}
+
+// Save writes the in-memory cache back to the secret at
+// MountPath/SecretPath, replacing its current contents.
+func (s *Store) Save() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, err := s.client.KVv2(s.cfg.MountPath).Put(context.TODO(), s.cfg.SecretPath, s.data)
+	return err
+}
+
+// normalizeKey upper-cases key so it matches the case DotEnv.Get looks keys
+// up with, regardless of how it's cased in Vault.
+func normalizeKey(key string) string {
+	return strings.ToUpper(key)
+}